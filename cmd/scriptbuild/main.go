@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/jw-dev/tombflow/pkg/script"
+	"github.com/jw-dev/tombflow/pkg/scriptyaml"
+)
+
+func fatal(err string) {
+	fmt.Printf("%v\nUsage: %v IN.json SCRIPT\n", err, os.Args[0])
+	os.Exit(1)
+}
+
+func main() {
+	if len(os.Args) < 3 {
+		fatal("Not enough arguments")
+	}
+
+	in, out := os.Args[1], os.Args[2]
+
+	data, err := os.ReadFile(in)
+	if err != nil {
+		fatal(fmt.Sprintf("Error reading file: %v", err))
+	}
+
+	s, err := scriptyaml.Unmarshal(data)
+	if err != nil {
+		log.Fatalf("Critical error parsing script document\n%v\n", err)
+	}
+
+	f, err := os.Create(out)
+	if err != nil {
+		log.Fatalf("Error creating %v\n%v\n", out, err)
+	}
+	defer f.Close()
+
+	if err := script.Write(f, s); err != nil {
+		log.Fatalf("Error writing script\n%v\n", err)
+	}
+}
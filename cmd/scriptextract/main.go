@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/jw-dev/tombflow/pkg/script"
+	"github.com/jw-dev/tombflow/pkg/scriptyaml"
+)
+
+func fatal(err string) {
+	fmt.Printf("%v\nUsage: %v SCRIPT OUT.json\n", err, os.Args[0])
+	os.Exit(1)
+}
+
+func main() {
+	if len(os.Args) < 3 {
+		fatal("Not enough arguments")
+	}
+
+	in, out := os.Args[1], os.Args[2]
+
+	f, err := os.Open(in)
+	if err != nil {
+		fatal(fmt.Sprintf("Error opening file: %v", err))
+	}
+	defer f.Close()
+
+	s, err := script.Read(f)
+	if err != nil {
+		log.Fatalf("Critical error reading script\n%v\n", err)
+	}
+
+	data, err := scriptyaml.Marshal(s)
+	if err != nil {
+		log.Fatalf("Error marshalling script\n%v\n", err)
+	}
+
+	if err := os.WriteFile(out, data, 0644); err != nil {
+		log.Fatalf("Error writing %v\n%v\n", out, err)
+	}
+}
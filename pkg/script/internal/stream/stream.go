@@ -0,0 +1,128 @@
+// Package stream provides bit-level reading and writing on top of an
+// io.Reader/io.Writer, for script layouts (older PC gameflow formats) that
+// pack an opcode and its argument into the high/low bits of a single word
+// rather than using two separate 16-bit words.
+package stream
+
+import "io"
+
+// Reader reads successive runs of bits, least-significant-bit first, from
+// an underlying byte stream. bitBuf is a uint64 (rather than uint32, which
+// would match the widest advertised ReadBits call) so that up to 7 bits
+// left over from a previous byte plus a full 32-bit read never overflow
+// the accumulator.
+type Reader struct {
+	r        io.Reader
+	bitBuf   uint64
+	bitCount uint
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: r}
+}
+
+func (r *Reader) fill() error {
+	var b [1]byte
+	if _, err := io.ReadFull(r.r, b[:]); err != nil {
+		return err
+	}
+	r.bitBuf |= uint64(b[0]) << r.bitCount
+	r.bitCount += 8
+	return nil
+}
+
+// ReadBits reads the next n bits (n <= 32) as an unsigned value.
+func (r *Reader) ReadBits(n uint) (uint32, error) {
+	for r.bitCount < n {
+		if err := r.fill(); err != nil {
+			return 0, err
+		}
+	}
+	v := uint32(r.bitBuf & (1<<n - 1))
+	r.bitBuf >>= n
+	r.bitCount -= n
+	return v, nil
+}
+
+// Align discards any partially-consumed byte so the next read starts fresh
+// on a byte boundary.
+func (r *Reader) Align() {
+	r.bitBuf = 0
+	r.bitCount = 0
+}
+
+// ReadString aligns to a byte boundary, reads n raw bytes, and XORs each
+// with key (if non-zero) to undo the same obfuscation script strings use.
+func (r *Reader) ReadString(n int, key byte) (string, error) {
+	r.Align()
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r.r, buf); err != nil {
+		return "", err
+	}
+	if key > 0 {
+		for i := range buf {
+			buf[i] ^= key
+		}
+	}
+	return string(buf), nil
+}
+
+// Writer is the inverse of Reader: it packs successive runs of bits into
+// bytes and writes them out as they fill. bitBuf is a uint64 for the same
+// reason as Reader.bitBuf: leftover bits from a previous call plus a full
+// 32-bit push must not overflow the accumulator.
+type Writer struct {
+	w        io.Writer
+	bitBuf   uint64
+	bitCount uint
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// PushBits appends the low n bits of v to the stream, flushing complete
+// bytes to the underlying writer as they accumulate.
+func (w *Writer) PushBits(v uint32, n uint) error {
+	w.bitBuf |= (uint64(v) & (1<<n - 1)) << w.bitCount
+	w.bitCount += n
+
+	for w.bitCount >= 8 {
+		if _, err := w.w.Write([]byte{byte(w.bitBuf)}); err != nil {
+			return err
+		}
+		w.bitBuf >>= 8
+		w.bitCount -= 8
+	}
+	return nil
+}
+
+// Flush pads any cached bits with zeroes out to a full byte and writes it,
+// leaving the stream aligned for a subsequent WriteString.
+func (w *Writer) Flush() error {
+	if w.bitCount == 0 {
+		return nil
+	}
+	if _, err := w.w.Write([]byte{byte(w.bitBuf)}); err != nil {
+		return err
+	}
+	w.bitBuf = 0
+	w.bitCount = 0
+	return nil
+}
+
+// WriteString flushes to a byte boundary, then writes s XORed with key (if
+// non-zero), matching the obfuscation script strings use.
+func (w *Writer) WriteString(s string, key byte) error {
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	buf := []byte(s)
+	if key > 0 {
+		for i := range buf {
+			buf[i] ^= key
+		}
+	}
+	_, err := w.w.Write(buf)
+	return err
+}
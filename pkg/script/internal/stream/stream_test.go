@@ -0,0 +1,124 @@
+package stream
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestReadBitsRoundTrip(t *testing.T) {
+	cases := []struct {
+		name   string
+		widths []uint
+		values []uint32
+	}{
+		{"byteAligned", []uint{16, 16}, []uint32{0x1234, 0xABCD}},
+		{"packedOpcodeAndArg", []uint{5, 11}, []uint32{17, 2000}},
+		{"mixedWidths", []uint{3, 13, 16}, []uint32{5, 8000, 0xFFFF}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewWriter(&buf)
+			for i, v := range c.values {
+				if err := w.PushBits(v, c.widths[i]); err != nil {
+					t.Fatalf("PushBits: %v", err)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush: %v", err)
+			}
+
+			encoded := append([]byte{}, buf.Bytes()...)
+
+			r := NewReader(bytes.NewReader(encoded))
+			decoded := make([]uint32, len(c.widths))
+			for i, width := range c.widths {
+				got, err := r.ReadBits(width)
+				if err != nil {
+					t.Fatalf("ReadBits: %v", err)
+				}
+				if want := c.values[i]; got != want {
+					t.Errorf("value %d: got %#x, want %#x", i, got, want)
+				}
+				decoded[i] = got
+			}
+
+			// Re-encode what we just read and compare the byte streams.
+			var reencoded bytes.Buffer
+			w2 := NewWriter(&reencoded)
+			for i, v := range decoded {
+				if err := w2.PushBits(v, c.widths[i]); err != nil {
+					t.Fatalf("PushBits (re-encode): %v", err)
+				}
+			}
+			if err := w2.Flush(); err != nil {
+				t.Fatalf("Flush (re-encode): %v", err)
+			}
+
+			if !bytes.Equal(encoded, reencoded.Bytes()) {
+				t.Errorf("re-encoded bytes = %x, want %x", reencoded.Bytes(), encoded)
+			}
+		})
+	}
+}
+
+func TestPushBitsWithCachedBitsDoesNotOverflow(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	if err := w.PushBits(0x5, 7); err != nil {
+		t.Fatalf("PushBits: %v", err)
+	}
+	if err := w.PushBits(0xDEADBEEF, 32); err != nil {
+		t.Fatalf("PushBits: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	r := NewReader(bytes.NewReader(buf.Bytes()))
+	if got, err := r.ReadBits(7); err != nil || got != 0x5 {
+		t.Fatalf("ReadBits(7) = %#x, %v, want 0x5, nil", got, err)
+	}
+	if got, err := r.ReadBits(32); err != nil || got != 0xDEADBEEF {
+		t.Fatalf("ReadBits(32) = %#x, %v, want 0xDEADBEEF, nil", got, err)
+	}
+}
+
+func TestReadBitsTruncated(t *testing.T) {
+	r := NewReader(bytes.NewReader([]byte{0x01}))
+	if _, err := r.ReadBits(16); err == nil {
+		t.Fatalf("expected error reading 16 bits from a single byte")
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	if err := w.PushBits(0x07, 3); err != nil {
+		t.Fatalf("PushBits: %v", err)
+	}
+	if err := w.WriteString("JUNGLE.PSX", 0x5A); err != nil {
+		t.Fatalf("WriteString: %v", err)
+	}
+
+	r := NewReader(bytes.NewReader(buf.Bytes()))
+	if _, err := r.ReadBits(3); err != nil {
+		t.Fatalf("ReadBits: %v", err)
+	}
+	got, err := r.ReadString(len("JUNGLE.PSX"), 0x5A)
+	if err != nil {
+		t.Fatalf("ReadString: %v", err)
+	}
+	if got != "JUNGLE.PSX" {
+		t.Errorf("ReadString = %q, want %q", got, "JUNGLE.PSX")
+	}
+}
+
+func TestReadStringTruncated(t *testing.T) {
+	r := NewReader(bytes.NewReader([]byte("ab")))
+	if _, err := r.ReadString(5, 0); err != io.ErrUnexpectedEOF {
+		t.Fatalf("ReadString error = %v, want io.ErrUnexpectedEOF", err)
+	}
+}
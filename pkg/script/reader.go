@@ -1,114 +1,239 @@
 package script
 
 import (
-	"encoding/binary"
+	"bytes"
+	"fmt"
 	"io"
+
+	"github.com/jw-dev/tombflow/pkg/script/internal/stream"
 )
 
-func Read(r io.Reader) *Script {
-	head := readHeader(r)
-	levelNames := readStringArray(r, head.NumLevels, head.XorKey)
-	chapterPaths := readStringArray(r, head.NumChapterScreens, head.XorKey)
-	titlePaths := readStringArray(r, head.NumTitles, head.XorKey)
-	fmvPaths := readStringArray(r, head.NumFmvs, head.XorKey)
-	levelPaths := readStringArray(r, head.NumLevels, head.XorKey)
-	cutscenePaths := readStringArray(r, head.NumCutscenes, head.XorKey)
-	gameFlow := readSequenceArray(r, head.NumLevels+1)
-	demoLevels := readDemoLevels(r, head.NumDemoLevels)
-	gameStrings := readGameStrings(r, head.XorKey)
-	extraStrings := readStringArray(r, 41, head.XorKey)
+// numExtraStrings is the fixed number of "extra strings" entries the binary
+// format always reserves, independent of how many a particular Script uses.
+const numExtraStrings = 41
+
+// opcodeArgWidth gives the bit width of each opcode's argument for the
+// current (TR3/TR4, word-aligned) layout, where every opcode and argument
+// is its own 16-bit word. Only argument widths come from this table:
+// decodeSequence always reads the opcode itself as a full 16-bit word, so
+// layouts that pack the argument into the opcode word are not decoded.
+var opcodeArgWidth = func() map[Opcode]uint {
+	widths := make(map[Opcode]uint, len(opcodeHasArgument))
+	for _, op := range opcodeHasArgument {
+		widths[op] = 16
+	}
+	return widths
+}()
+
+func Read(r io.Reader) (*Script, error) {
+	sr := newStreamReader(r)
+
+	head, err := readHeader(sr)
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+
+	levelNames, err := readStringArray(sr, head.NumLevels, head.XorKey)
+	if err != nil {
+		return nil, fmt.Errorf("reading level names: %w", err)
+	}
+	chapterPaths, err := readStringArray(sr, head.NumChapterScreens, head.XorKey)
+	if err != nil {
+		return nil, fmt.Errorf("reading chapter paths: %w", err)
+	}
+	titlePaths, err := readStringArray(sr, head.NumTitles, head.XorKey)
+	if err != nil {
+		return nil, fmt.Errorf("reading title paths: %w", err)
+	}
+	fmvPaths, err := readStringArray(sr, head.NumFmvs, head.XorKey)
+	if err != nil {
+		return nil, fmt.Errorf("reading fmv paths: %w", err)
+	}
+	levelPaths, err := readStringArray(sr, head.NumLevels, head.XorKey)
+	if err != nil {
+		return nil, fmt.Errorf("reading level paths: %w", err)
+	}
+	cutscenePaths, err := readStringArray(sr, head.NumCutscenes, head.XorKey)
+	if err != nil {
+		return nil, fmt.Errorf("reading cutscene paths: %w", err)
+	}
+	gameFlow, err := readSequenceArray(sr, head.NumLevels+1)
+	if err != nil {
+		return nil, fmt.Errorf("reading gameflow: %w", err)
+	}
+	demoLevels, err := readDemoLevels(sr, head.NumDemoLevels)
+	if err != nil {
+		return nil, fmt.Errorf("reading demo levels: %w", err)
+	}
+	gameStrings, err := readGameStrings(sr, head.XorKey)
+	if err != nil {
+		return nil, fmt.Errorf("reading game strings: %w", err)
+	}
+	extraStrings, err := readStringArray(sr, numExtraStrings, head.XorKey)
+	if err != nil {
+		return nil, fmt.Errorf("reading extra strings: %w", err)
+	}
 	levels := joinLevels(levelNames, levelPaths, chapterPaths, gameFlow, demoLevels)
 
 	for i := 0; i < 4; i++ {
-		puzzles := readStringArray(r, head.NumLevels, head.XorKey)
+		puzzles, err := readStringArray(sr, head.NumLevels, head.XorKey)
+		if err != nil {
+			return nil, fmt.Errorf("reading puzzle strings %d: %w", i, err)
+		}
 		for j := 0; j < int(head.NumLevels); j++ {
 			levels[j].Puzzles[i] = puzzles[j]
 		}
 	}
 
 	for i := 0; i < 2; i++ {
-		puzzles := readStringArray(r, head.NumLevels, head.XorKey)
+		pickups, err := readStringArray(sr, head.NumLevels, head.XorKey)
+		if err != nil {
+			return nil, fmt.Errorf("reading pickup strings %d: %w", i, err)
+		}
 		for j := 0; j < int(head.NumLevels); j++ {
-			levels[j].Pickups[i] = puzzles[j]
+			levels[j].Pickups[i] = pickups[j]
 		}
 	}
 
 	for i := 0; i < 4; i++ {
-		puzzles := readStringArray(r, head.NumLevels, head.XorKey)
+		keys, err := readStringArray(sr, head.NumLevels, head.XorKey)
+		if err != nil {
+			return nil, fmt.Errorf("reading key strings %d: %w", i, err)
+		}
 		for j := 0; j < int(head.NumLevels); j++ {
-			levels[j].Keys[i] = puzzles[j]
+			levels[j].Keys[i] = keys[j]
 		}
 	}
 
-	return &Script{
-		Version:      head.Version,
-		Description:  string(head.Description[:]),
-		Levels:       levels,
-		Titles:       titlePaths,
-		Fmvs:         fmvPaths,
-		Cutscenes:    cutscenePaths,
-		GameStrings:  gameStrings,
-		ExtraStrings: extraStrings,
+	titleFlow := Sequence{}
+	if len(gameFlow) > 0 {
+		titleFlow = gameFlow[0]
 	}
+
+	return &Script{
+		Version:         head.Version,
+		Description:     string(head.Description[:]),
+		Lang:            Language(head.LanguageId),
+		Levels:          levels,
+		Titles:          titlePaths,
+		Fmvs:            fmvPaths,
+		Cutscenes:       cutscenePaths,
+		GameStrings:     gameStrings,
+		ExtraStrings:    extraStrings,
+		TitleFlow:       titleFlow,
+		XorKey:          head.XorKey,
+		GameflowSize:    head.GameflowSize,
+		FirstOption:     head.FirstOption,
+		TitleReplace:    head.TitleReplace,
+		OnDeathDemoMode: head.OnDeathDemoMode,
+		OnDeathInGame:   head.OnDeathInGame,
+		DemoTime:        head.DemoTime,
+		OnDemoInterrupt: head.OnDemoInterrupt,
+		OnDemoEnd:       head.OnDemoEnd,
+		TitleSoundId:    head.TitleSoundId,
+		SingleLevel:     head.SingleLevel,
+		Flags:           head.Flags,
+		SecretSoundId:   head.SecretSoundId,
+	}, nil
 }
 
-func readHeader(r io.Reader) *header {
+func readHeader(sr *streamReader) (*header, error) {
 	h := header{}
-	binary.Read(r, binary.LittleEndian, &h)
-	return &h
+	if err := sr.ReadStruct(&h); err != nil {
+		return nil, err
+	}
+	return &h, nil
 }
 
-func readMultiByteArray(r io.Reader, count uint16) *multiByteArray {
+func readMultiByteArray(sr *streamReader, count uint16) (*multiByteArray, error) {
 	offsets := make([]uint16, count)
-	binary.Read(r, binary.LittleEndian, &offsets)
+	if err := sr.ReadStruct(&offsets); err != nil {
+		return nil, fmt.Errorf("reading offsets: %w", err)
+	}
 
-	size := uint16(0)
-	binary.Read(r, binary.LittleEndian, &size)
+	size, err := sr.ReadU16()
+	if err != nil {
+		return nil, fmt.Errorf("reading size: %w", err)
+	}
 
-	data := make([]uint8, size)
-	binary.Read(r, binary.LittleEndian, &data)
+	data, err := sr.ReadBytes(int(size))
+	if err != nil {
+		return nil, fmt.Errorf("reading data: %w", err)
+	}
 
-	return newMultiByteArray(offsets, data)
+	return newMultiByteArray(offsets, data), nil
 }
 
-func readStringArray(r io.Reader, count uint16, xor byte) []string {
-	m := readMultiByteArray(r, count)
-	return m.Strings(xor)
+func readStringArray(sr *streamReader, count uint16, xor byte) ([]string, error) {
+	m, err := readMultiByteArray(sr, count)
+	if err != nil {
+		return nil, err
+	}
+	return m.Strings(xor), nil
 }
 
-func readSequenceArray(r io.Reader, count uint16) []Sequence {
+func readSequenceArray(sr *streamReader, count uint16) ([]Sequence, error) {
+	m, err := readMultiByteArray(sr, count)
+	if err != nil {
+		return nil, err
+	}
+
 	seqs := []Sequence{}
 
-	m := readMultiByteArray(r, count)
-	chunks := m.U16()
+	for chunkIdx, chunk := range m.chunks() {
+		seq, err := decodeSequence(chunk)
+		if err != nil {
+			return nil, fmt.Errorf("truncated sequence in chunk %d: %w", chunkIdx, err)
+		}
+		seqs = append(seqs, seq)
+	}
 
-	for _, chunk := range chunks {
-		seq := Sequence{}
+	return seqs, nil
+}
 
-		for i := 0; i < len(chunk); i++ {
-			typ := Opcode(chunk[i])
-			arg := uint16(0)
-			if typ.hasArg() {
-				i = i + 1
-				arg = chunk[i]
+// decodeSequence decodes one chunk's worth of opcode/argument words through
+// the bit reader, consuming exactly len(chunk) bytes worth of bits.
+func decodeSequence(chunk []byte) (Sequence, error) {
+	br := stream.NewReader(bytes.NewReader(chunk))
+	totalBits := uint(len(chunk)) * 8
+
+	seq := Sequence{}
+	for bitsRead := uint(0); bitsRead < totalBits; {
+		opBits, err := br.ReadBits(16)
+		if err != nil {
+			return nil, fmt.Errorf("reading opcode at bit %d: %w", bitsRead, err)
+		}
+		bitsRead += 16
+
+		typ := Opcode(opBits)
+		arg := uint16(0)
+		if width, ok := opcodeArgWidth[typ]; ok {
+			argBits, err := br.ReadBits(width)
+			if err != nil {
+				return nil, fmt.Errorf("opcode %v at bit %d has no argument: %w", typ, bitsRead, err)
 			}
-			seq = append(seq, Command{Op: typ, Arg: arg})
+			bitsRead += width
+			arg = uint16(argBits)
 		}
 
-		seqs = append(seqs, seq)
+		seq = append(seq, Command{Op: typ, Arg: arg})
 	}
 
-	return seqs
+	return seq, nil
 }
 
-func readDemoLevels(r io.Reader, count uint16) []uint16 {
+func readDemoLevels(sr *streamReader, count uint16) ([]uint16, error) {
 	levels := make([]uint16, count)
-	binary.Read(r, binary.LittleEndian, &levels)
-	return levels
+	if err := sr.ReadStruct(&levels); err != nil {
+		return nil, err
+	}
+	return levels, nil
 }
 
-func readGameStrings(r io.Reader, xor byte) []string {
-	count := uint16(0)
-	binary.Read(r, binary.LittleEndian, &count)
-	return readStringArray(r, count, xor)
+func readGameStrings(sr *streamReader, xor byte) ([]string, error) {
+	count, err := sr.ReadU16()
+	if err != nil {
+		return nil, fmt.Errorf("reading count: %w", err)
+	}
+	return readStringArray(sr, count, xor)
 }
@@ -0,0 +1,97 @@
+package script
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestReadHeaderTruncated(t *testing.T) {
+	cases := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", []byte{}},
+		{"shortOfFullHeader", make([]byte, 10)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sr := newStreamReader(bytes.NewReader(c.data))
+			if _, err := readHeader(sr); err == nil {
+				t.Fatalf("expected error reading truncated header, got nil")
+			}
+		})
+	}
+}
+
+func TestReadMultiByteArrayTruncated(t *testing.T) {
+	cases := []struct {
+		name  string
+		data  []byte
+		count uint16
+	}{
+		{"truncatedOffsets", []byte{0x00}, 2},
+		{"missingSize", u16le(0, 2), 2},
+		{"truncatedData", append(u16le(0), u16le(4)...), 1},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sr := newStreamReader(bytes.NewReader(c.data))
+			if _, err := readMultiByteArray(sr, c.count); err == nil {
+				t.Fatalf("expected error reading truncated multi-byte array, got nil")
+			}
+		})
+	}
+}
+
+func TestReadSequenceArrayTruncatedArgument(t *testing.T) {
+	// One chunk containing a single OpLevel opcode with no trailing argument.
+	offsets := u16le(0)
+	chunk := u16le(uint16(OpLevel))
+	size := u16le(uint16(len(chunk)))
+
+	data := append(append(offsets, size...), chunk...)
+
+	sr := newStreamReader(bytes.NewReader(data))
+	if _, err := readSequenceArray(sr, 1); err == nil {
+		t.Fatalf("expected error for opcode missing its argument, got nil")
+	}
+}
+
+func TestReadDemoLevelsTruncated(t *testing.T) {
+	sr := newStreamReader(bytes.NewReader(u16le(1)))
+	if _, err := readDemoLevels(sr, 2); err == nil {
+		t.Fatalf("expected error reading truncated demo levels, got nil")
+	}
+}
+
+func TestReadGameStringsTruncated(t *testing.T) {
+	cases := []struct {
+		name string
+		data []byte
+	}{
+		{"missingCount", []byte{}},
+		{"missingOffsets", u16le(2)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sr := newStreamReader(bytes.NewReader(c.data))
+			if _, err := readGameStrings(sr, 0); err == nil {
+				t.Fatalf("expected error reading truncated game strings, got nil")
+			}
+		})
+	}
+}
+
+// u16le encodes each value as a little-endian uint16 and concatenates the
+// results, for building minimal truncated binary fixtures.
+func u16le(values ...uint16) []byte {
+	buf := make([]byte, len(values)*2)
+	for i, v := range values {
+		binary.LittleEndian.PutUint16(buf[i*2:], v)
+	}
+	return buf
+}
@@ -1,9 +1,8 @@
 package script
 
 import (
-	"encoding/binary"
+	"encoding/json"
 	"fmt"
-	"io"
 )
 
 const (
@@ -129,6 +128,38 @@ func (l Language) String() string {
 	return "Unknown"
 }
 
+// MarshalJSON renders the language by name where one is known, so extracted
+// scripts are editable without memorising LanguageId values. Unknown
+// languages fall back to their raw numeric id.
+func (l Language) MarshalJSON() ([]byte, error) {
+	if int(l) < len(tLanguages) {
+		return json.Marshal(tLanguages[l])
+	}
+	return json.Marshal(uint8(l))
+}
+
+// UnmarshalJSON accepts either a language name (as produced by MarshalJSON)
+// or a raw numeric id.
+func (l *Language) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		for i, n := range tLanguages {
+			if n == name {
+				*l = Language(i)
+				return nil
+			}
+		}
+		return fmt.Errorf("script: unknown language name %q", name)
+	}
+
+	var n uint8
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("script: invalid language %s: %w", data, err)
+	}
+	*l = Language(n)
+	return nil
+}
+
 type Opcode int32
 
 func (o Opcode) hasArg() bool {
@@ -141,12 +172,47 @@ func (o Opcode) hasArg() bool {
 }
 
 func (o Opcode) String() string {
-	if int(o) < len(tOpcodes) {
+	if int(o) >= 0 && int(o) < len(tOpcodes) {
 		return tOpcodes[o]
 	}
 	return "Unknown"
 }
 
+// MarshalJSON renders the opcode by name where tOpcodes has one, so
+// extracted scripts are editable without memorising Opcode values.
+// Opcodes outside that table (e.g. OpDisable, OpSavedGame, OpExitToTitle,
+// OpExitGame) fall back to their raw numeric value rather than the lossy
+// "Unknown" string, so a round trip through Marshal/Unmarshal never loses
+// the actual opcode.
+func (o Opcode) MarshalJSON() ([]byte, error) {
+	if int(o) >= 0 && int(o) < len(tOpcodes) {
+		return json.Marshal(tOpcodes[o])
+	}
+	return json.Marshal(int32(o))
+}
+
+// UnmarshalJSON accepts either an opcode name (as produced by MarshalJSON
+// for opcodes in tOpcodes) or a raw numeric value.
+func (o *Opcode) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		for i, n := range tOpcodes {
+			if n == name {
+				*o = Opcode(i)
+				return nil
+			}
+		}
+		return fmt.Errorf("script: unknown opcode name %q", name)
+	}
+
+	var n int32
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("script: invalid opcode %s: %w", data, err)
+	}
+	*o = Opcode(n)
+	return nil
+}
+
 type Command struct {
 	Op  Opcode
 	Arg uint16
@@ -182,75 +248,67 @@ type Script struct {
 	Cutscenes    []string
 	GameStrings  []string
 	ExtraStrings []string
-}
-
-func Read(r io.Reader) *Script {
-	head := readHeader(r)
-	levelNames := readStringArray(r, head.NumLevels, head.XorKey)
-	chapterPaths := readStringArray(r, head.NumChapterScreens, head.XorKey)
-	titlePaths := readStringArray(r, head.NumTitles, head.XorKey)
-	fmvPaths := readStringArray(r, head.NumFmvs, head.XorKey)
-	levelPaths := readStringArray(r, head.NumLevels, head.XorKey)
-	cutscenePaths := readStringArray(r, head.NumCutscenes, head.XorKey)
-	gameFlow := readSequenceArray(r, head.NumLevels+1)
-	demoLevels := readDemoLevels(r, head.NumDemoLevels)
-	gameStrings := readGameStrings(r, head.XorKey)
-	extraStrings := readStringArray(r, 41, head.XorKey)
-	levels := joinLevels(levelNames, levelPaths, chapterPaths, gameFlow, demoLevels)
-
-	for i := 0; i < 4; i++ {
-		puzzles := readStringArray(r, head.NumLevels, head.XorKey)
-		for j := 0; j < int(head.NumLevels); j++ {
-			levels[j].Puzzles[i] = puzzles[j]
-		}
-	}
 
-	for i := 0; i < 2; i++ {
-		puzzles := readStringArray(r, head.NumLevels, head.XorKey)
-		for j := 0; j < int(head.NumLevels); j++ {
-			levels[j].Pickups[i] = puzzles[j]
-		}
-	}
-
-	for i := 0; i < 4; i++ {
-		puzzles := readStringArray(r, head.NumLevels, head.XorKey)
-		for j := 0; j < int(head.NumLevels); j++ {
-			levels[j].Keys[i] = puzzles[j]
-		}
-	}
-
-	return &Script{
-		Version:      head.Version,
-		Description:  string(head.Description[:]),
-		Levels:       levels,
-		Titles:       titlePaths,
-		Fmvs:         fmvPaths,
-		Cutscenes:    cutscenePaths,
-		GameStrings:  gameStrings,
-		ExtraStrings: extraStrings,
-	}
+	// TitleFlow is the sequence played before any level is loaded (e.g. the
+	// attract/title screen flow). It is the first entry of the on-disk
+	// gameflow array and isn't associated with any particular Level.
+	TitleFlow Sequence
+
+	// XorKey is the byte used to obfuscate strings in the binary format.
+	// It's carried on Script so Write can re-emit strings the same way
+	// they were read.
+	XorKey byte
+
+	// The fields below are carried verbatim from the binary header so
+	// Write can re-emit them unchanged. They aren't otherwise interpreted
+	// by this package.
+	GameflowSize    uint16
+	FirstOption     int32
+	TitleReplace    int32
+	OnDeathDemoMode int32
+	OnDeathInGame   int32
+	DemoTime        uint32
+	OnDemoInterrupt int32
+	OnDemoEnd       int32
+	TitleSoundId    uint16
+	SingleLevel     uint16
+	Flags           uint16
+	SecretSoundId   uint16
 }
 
-// FormatCommand formats a comand, replacing any arguments with the relevent item. For example, (LoadLevel 0) would return "Load Level JUNGLE.PSX" (in TRIII)
+// FormatCommand formats a comand, replacing any arguments with the relevent item, in Script.Lang. For example, (OpLevel 0) would return "Play Level 'JUNGLE.PSX' (Jungle)" (in TRIII, English)
 func (s Script) FormatCommand(c Command) string {
+	op := c.Op.StringIn(s.Lang)
 	if !c.Op.hasArg() {
-		return c.Op.String()
+		return op
 	}
 	switch c.Op {
 	case OpLoadPic:
-		return fmt.Sprintf("%v '%v'", c.Op, s.Levels[c.Arg].Chapter)
+		return fmt.Sprintf("%v '%v'", op, s.Levels[c.Arg].Chapter)
 	case OpFmv:
-		return fmt.Sprintf("%v '%v'", c.Op, s.Fmvs[c.Arg])
+		return fmt.Sprintf("%v '%v'", op, s.Fmvs[c.Arg])
 	case OpLevel:
 		level := s.Levels[c.Arg]
-		return fmt.Sprintf("%v '%v' (%v)", c.Op, level.Path, level.Name)
+		return fmt.Sprintf("%v '%v' (%v)", op, level.Path, level.Name)
 	case OpCine:
-		return fmt.Sprintf("%v '%v'", c.Op, s.Cutscenes[c.Arg])
+		return fmt.Sprintf("%v '%v'", op, s.Cutscenes[c.Arg])
 	default:
-		return fmt.Sprintf("%v %v", c.Op, c.Arg)
+		return fmt.Sprintf("%v %v", op, c.Arg)
 	}
 }
 
+// FormatEvent formats a trigger event's name in Script.Lang.
+func (s Script) FormatEvent(e Event) string {
+	return e.StringIn(s.Lang)
+}
+
+// SetLanguage changes the language used by FormatCommand and FormatEvent,
+// letting callers re-render a script's flows in any supported language
+// without re-parsing it.
+func (s *Script) SetLanguage(l Language) {
+	s.Lang = l
+}
+
 type header struct {
 	Version           uint32
 	Description       [256]byte
@@ -280,12 +338,6 @@ type header struct {
 	_                 [4]byte
 }
 
-func readHeader(r io.Reader) *header {
-	h := header{}
-	binary.Read(r, binary.LittleEndian, &h)
-	return &h
-}
-
 type multiByteArray struct {
 	offsets []uint16
 	data    []uint8
@@ -321,82 +373,21 @@ func (m multiByteArray) Strings(xor byte) []string {
 	return strs
 }
 
-func (m multiByteArray) U16() [][]uint16 {
-	u16 := make([]uint16, len(m.data)/2)
-
-	for i := 0; i < len(u16); i++ {
-		u16[i] = binary.LittleEndian.Uint16(m.data[i*2:])
-	}
-
-	chunks := [][]uint16{}
-
+// chunks splits the backing data into the raw byte ranges described by
+// offsets, without decoding them - used by formats (like Sequence) that
+// need bit-level rather than word-level decoding.
+func (m multiByteArray) chunks() [][]byte {
+	chunks := make([][]byte, len(m.offsets))
 	for i, offset := range m.offsets {
 		if i == len(m.offsets)-1 {
-			chunks = append(chunks, u16[offset/2:])
-			break
+			chunks[i] = m.data[offset:]
+			continue
 		}
-		to := m.offsets[i+1]
-		chunks = append(chunks, u16[offset/2:to/2])
+		chunks[i] = m.data[offset:m.offsets[i+1]]
 	}
-
 	return chunks
 }
 
-func readMultiByteArray(r io.Reader, count uint16) *multiByteArray {
-	offsets := make([]uint16, count)
-	binary.Read(r, binary.LittleEndian, &offsets)
-
-	size := uint16(0)
-	binary.Read(r, binary.LittleEndian, &size)
-
-	data := make([]uint8, size)
-	binary.Read(r, binary.LittleEndian, &data)
-
-	return newMultiByteArray(offsets, data)
-}
-
-func readStringArray(r io.Reader, count uint16, xor byte) []string {
-	m := readMultiByteArray(r, count)
-	return m.Strings(xor)
-}
-
-func readSequenceArray(r io.Reader, count uint16) []Sequence {
-	seqs := []Sequence{}
-
-	m := readMultiByteArray(r, count)
-	chunks := m.U16()
-
-	for _, chunk := range chunks {
-		seq := Sequence{}
-
-		for i := 0; i < len(chunk); i++ {
-			typ := Opcode(chunk[i])
-			arg := uint16(0)
-			if typ.hasArg() {
-				i = i + 1
-				arg = chunk[i]
-			}
-			seq = append(seq, Command{Op: typ, Arg: arg})
-		}
-
-		seqs = append(seqs, seq)
-	}
-
-	return seqs
-}
-
-func readDemoLevels(r io.Reader, count uint16) []uint16 {
-	levels := make([]uint16, count)
-	binary.Read(r, binary.LittleEndian, &levels)
-	return levels
-}
-
-func readGameStrings(r io.Reader, xor byte) []string {
-	count := uint16(0)
-	binary.Read(r, binary.LittleEndian, &count)
-	return readStringArray(r, count, xor)
-}
-
 func joinLevels(names []string, paths []string, chaps []string, flow []Sequence, demos []uint16) []Level {
 	l := make([]Level, len(names))
 
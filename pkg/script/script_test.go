@@ -0,0 +1,62 @@
+package script
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOpcodeJSONRoundTrip(t *testing.T) {
+	cases := []struct {
+		name string
+		op   Opcode
+		want string
+	}{
+		{"named", OpLevel, `"Play Level"`},
+		{"disabled", OpDisable, "-1"},
+		{"savedGameNotInTOpcodes", OpSavedGame, "23"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			data, err := json.Marshal(c.op)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			if string(data) != c.want {
+				t.Errorf("Marshal(%v) = %s, want %s", c.op, data, c.want)
+			}
+
+			var got Opcode
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if got != c.op {
+				t.Errorf("round trip = %v, want %v", got, c.op)
+			}
+		})
+	}
+}
+
+func TestOpcodeStringNegativeDoesNotPanic(t *testing.T) {
+	if got, want := Opcode(OpDisable).String(), "Unknown"; got != want {
+		t.Errorf("Opcode(OpDisable).String() = %q, want %q", got, want)
+	}
+}
+
+func TestLanguageJSONRoundTrip(t *testing.T) {
+	data, err := json.Marshal(LGerman)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if string(data) != `"German"` {
+		t.Errorf("Marshal(LGerman) = %s, want %q", data, `"German"`)
+	}
+
+	var got Language
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got != LGerman {
+		t.Errorf("round trip = %v, want %v", got, LGerman)
+	}
+}
@@ -0,0 +1,44 @@
+package script
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// streamReader wraps an io.Reader with typed little-endian reads that
+// surface binary.Read's error instead of discarding it, so a short or
+// corrupt script produces a descriptive error rather than a silently
+// zero-filled Script.
+type streamReader struct {
+	r io.Reader
+}
+
+func newStreamReader(r io.Reader) *streamReader {
+	return &streamReader{r: r}
+}
+
+// ReadStruct reads binary.Size(v) bytes into v, which must be a pointer to
+// a fixed-size value (or a pre-allocated slice of them).
+func (s *streamReader) ReadStruct(v interface{}) error {
+	return binary.Read(s.r, binary.LittleEndian, v)
+}
+
+func (s *streamReader) ReadU16() (uint16, error) {
+	var v uint16
+	err := s.ReadStruct(&v)
+	return v, err
+}
+
+func (s *streamReader) ReadU32() (uint32, error) {
+	var v uint32
+	err := s.ReadStruct(&v)
+	return v, err
+}
+
+func (s *streamReader) ReadBytes(n int) ([]byte, error) {
+	buf := make([]byte, n)
+	if err := s.ReadStruct(&buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
@@ -0,0 +1,223 @@
+package script
+
+import "fmt"
+
+// tOpcodesFrench, tOpcodesGerman, tOpcodesItalian and tOpcodesSpanish mirror
+// tOpcodes index-for-index, covering the languages the retail scripts
+// actually ship in (French, German, Italian, Spanish).
+var tOpcodesFrench = [...]string{
+	"Image",
+	"Début de liste",
+	"Fin de liste",
+	"Afficher la vidéo",
+	"Jouer le niveau",
+	"Afficher la cinématique",
+	"Fin de niveau",
+	"Jouer la démo",
+	"Aller à la séquence",
+	"Fin de séquence",
+	"Jouer la bande son",
+	"Coucher de soleil",
+	"Charger l'image",
+	"Eau mortelle",
+	"Retirer les armes",
+	"Jeu terminé",
+	"Angle de cinématique",
+	"Pas de sol",
+	"Inventaire de départ",
+	"Animation de départ",
+	"Secrets",
+	"Tuer pour terminer",
+	"Retirer les munitions",
+}
+
+var tOpcodesGerman = [...]string{
+	"Bild",
+	"Listenanfang",
+	"Listenende",
+	"FMV abspielen",
+	"Level starten",
+	"Zwischensequenz abspielen",
+	"Level beenden",
+	"Demo abspielen",
+	"Zu Sequenz springen",
+	"Sequenzende",
+	"Musikstück abspielen",
+	"Sonnenuntergang",
+	"Bild laden",
+	"Tödliches Wasser",
+	"Waffen entfernen",
+	"Spiel abgeschlossen",
+	"Kamerawinkel setzen",
+	"Kein Boden",
+	"Startinventar",
+	"Startanimation",
+	"Geheimnisse",
+	"Töten zum Abschluss",
+	"Munition entfernen",
+}
+
+var tOpcodesItalian = [...]string{
+	"Immagine",
+	"Inizio lista",
+	"Fine lista",
+	"Mostra filmato",
+	"Avvia livello",
+	"Mostra sequenza",
+	"Fine livello",
+	"Riproduci demo",
+	"Vai alla sequenza",
+	"Fine sequenza",
+	"Riproduci colonna sonora",
+	"Tramonto",
+	"Carica immagine",
+	"Acqua mortale",
+	"Rimuovi armi",
+	"Gioco completato",
+	"Angolo cinematica",
+	"Nessun pavimento",
+	"Inventario iniziale",
+	"Animazione iniziale",
+	"Segreti",
+	"Uccidi per completare",
+	"Rimuovi munizioni",
+}
+
+var tOpcodesSpanish = [...]string{
+	"Imagen",
+	"Inicio de lista",
+	"Fin de lista",
+	"Mostrar vídeo",
+	"Cargar nivel",
+	"Mostrar escena",
+	"Fin de nivel",
+	"Reproducir demo",
+	"Saltar a secuencia",
+	"Fin de secuencia",
+	"Reproducir pista",
+	"Atardecer",
+	"Cargar imagen",
+	"Agua mortal",
+	"Quitar armas",
+	"Juego completado",
+	"Ángulo de escena",
+	"Sin suelo",
+	"Inventario inicial",
+	"Animación inicial",
+	"Secretos",
+	"Matar para completar",
+	"Quitar munición",
+}
+
+// tEventsFrench, tEventsGerman, tEventsItalian and tEventsSpanish mirror
+// tEvents index-for-index.
+var tEventsFrench = [...]string{
+	"Charger le niveau",
+	"Charger une partie sauvegardée",
+	"Charger la cinématique",
+	"Charger la vidéo",
+	"Charger une démo aléatoire",
+	"Retour au titre",
+	"Quitter le jeu",
+}
+
+var tEventsGerman = [...]string{
+	"Level laden",
+	"Spielstand laden",
+	"Zwischensequenz laden",
+	"FMV laden",
+	"Zufällige Demo laden",
+	"Zum Titel zurückkehren",
+	"Spiel beenden",
+}
+
+var tEventsItalian = [...]string{
+	"Carica livello",
+	"Carica partita salvata",
+	"Carica sequenza",
+	"Carica filmato",
+	"Carica demo casuale",
+	"Torna al titolo",
+	"Esci dal gioco",
+}
+
+var tEventsSpanish = [...]string{
+	"Cargar nivel",
+	"Cargar partida guardada",
+	"Cargar escena",
+	"Cargar vídeo",
+	"Cargar demo aleatoria",
+	"Volver al título",
+	"Salir del juego",
+}
+
+// opcodeTranslations indexes into the per-language opcode tables by
+// Language. Languages with no dedicated table (e.g. LAmerican, LJapanese)
+// fall back to English.
+var opcodeTranslations = map[Language][]string{
+	LEnglish: tOpcodes[:],
+	LFrench:  tOpcodesFrench[:],
+	LGerman:  tOpcodesGerman[:],
+	LItalian: tOpcodesItalian[:],
+	LSpanish: tOpcodesSpanish[:],
+}
+
+// eventTranslations indexes into the per-language event tables by Language.
+var eventTranslations = map[Language][]string{
+	LEnglish: tEvents[:],
+	LFrench:  tEventsFrench[:],
+	LGerman:  tEventsGerman[:],
+	LItalian: tEventsItalian[:],
+	LSpanish: tEventsSpanish[:],
+}
+
+// StringIn returns the opcode's name in the given language, falling back to
+// English if the language has no translation table or no entry at this
+// index.
+func (o Opcode) StringIn(lang Language) string {
+	if table, ok := opcodeTranslations[lang]; ok && int(o) >= 0 && int(o) < len(table) {
+		return table[o]
+	}
+	return o.String()
+}
+
+// Event identifies a script trigger event, as distinct from a gameflow
+// Opcode. Event codes are the values commandOpcodeMap decodes into Opcodes.
+type Event uint16
+
+const (
+	EventLoadLevel Event = iota
+	EventLoadSavedGame
+	EventLoadCutscene
+	EventLoadFmv
+	EventLoadRandomDemo
+	EventExitToTitle
+	EventExitGame
+)
+
+// String returns the event's English name.
+func (e Event) String() string {
+	if int(e) < len(tEvents) {
+		return tEvents[e]
+	}
+	return "Unknown"
+}
+
+// StringIn returns the event's name in the given language, falling back to
+// English if the language has no translation table or no entry at this
+// index.
+func (e Event) StringIn(lang Language) string {
+	if table, ok := eventTranslations[lang]; ok && int(e) < len(table) {
+		return table[e]
+	}
+	return e.String()
+}
+
+// StringIn returns the command's formatted name in the given language,
+// localising the opcode name but leaving any numeric argument as-is.
+func (c Command) StringIn(lang Language) string {
+	if c.Op.hasArg() {
+		return fmt.Sprintf("%v %v", c.Op.StringIn(lang), c.Arg)
+	}
+	return c.Op.StringIn(lang)
+}
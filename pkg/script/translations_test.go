@@ -0,0 +1,45 @@
+package script
+
+import "testing"
+
+func TestOpcodeStringIn(t *testing.T) {
+	cases := []struct {
+		lang Language
+		want string
+	}{
+		{LEnglish, "Play Level"},
+		{LFrench, "Jouer le niveau"},
+		{LGerman, "Level starten"},
+		{LItalian, "Avvia livello"},
+		{LSpanish, "Cargar nivel"},
+		{LJapanese, "Play Level"}, // no table: falls back to English
+	}
+
+	for _, c := range cases {
+		if got := OpLevel.StringIn(c.lang); got != c.want {
+			t.Errorf("OpLevel.StringIn(%v) = %q, want %q", c.lang, got, c.want)
+		}
+	}
+}
+
+func TestOpcodeStringInNegativeDoesNotPanic(t *testing.T) {
+	if got, want := Opcode(OpDisable).StringIn(LFrench), "Unknown"; got != want {
+		t.Errorf("Opcode(OpDisable).StringIn(LFrench) = %q, want %q", got, want)
+	}
+}
+
+func TestScriptFormatCommandUsesLang(t *testing.T) {
+	s := Script{Lang: LGerman}
+	got := s.FormatCommand(Command{Op: OpSecrets, Arg: 3})
+	want := "Geheimnisse 3"
+	if got != want {
+		t.Errorf("FormatCommand = %q, want %q", got, want)
+	}
+}
+
+func TestScriptFormatEvent(t *testing.T) {
+	s := Script{Lang: LItalian}
+	if got, want := s.FormatEvent(EventExitGame), "Esci dal gioco"; got != want {
+		t.Errorf("FormatEvent = %q, want %q", got, want)
+	}
+}
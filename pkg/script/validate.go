@@ -0,0 +1,128 @@
+package script
+
+import "fmt"
+
+// Severity classifies how serious a Diagnostic is.
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+)
+
+func (sv Severity) String() string {
+	if sv == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// maxListNesting is the deepest OpListStart/OpListEnd nesting the engine
+// supports within a single Flow.
+const maxListNesting = 1
+
+// Diagnostic describes a single structural problem found by Validate, in a
+// form a linter or editor can consume directly.
+type Diagnostic struct {
+	Level    int
+	Command  int
+	Severity Severity
+	Code     string
+	Message  string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%v: level %d, command %d: [%v] %v", d.Severity, d.Level, d.Command, d.Code, d.Message)
+}
+
+// Validate walks every Level.Flow in s and reports structural problems that
+// would otherwise crash the game at runtime: unmatched or over-nested list
+// blocks, opcode arguments that index outside their target slice, jump
+// targets pointing at non-existent sequences, sequences missing a trailing
+// OpEnd, unknown opcodes, and demo levels with no flow to play.
+func Validate(s *Script) []Diagnostic {
+	diags := []Diagnostic{}
+
+	for i, level := range s.Levels {
+		diags = append(diags, validateFlow(s, i, level.Flow)...)
+
+		if level.IsDemo && len(level.Flow) == 0 {
+			diags = append(diags, Diagnostic{
+				Level:    i,
+				Command:  -1,
+				Severity: SeverityWarning,
+				Code:     "demo-level-missing-flow",
+				Message:  "level is flagged as a demo level but has no flow",
+			})
+		}
+	}
+
+	return diags
+}
+
+func validateFlow(s *Script, level int, flow Sequence) []Diagnostic {
+	diags := []Diagnostic{}
+	listDepth := 0
+
+	for j, cmd := range flow {
+		switch cmd.Op {
+		case OpListStart:
+			listDepth++
+			if listDepth > maxListNesting {
+				diags = append(diags, diag(level, j, "list-nesting-too-deep", "OpListStart nests deeper than the engine supports"))
+			}
+		case OpListEnd:
+			if listDepth == 0 {
+				diags = append(diags, diag(level, j, "list-end-unmatched", "OpListEnd has no matching OpListStart"))
+			} else {
+				listDepth--
+			}
+		case OpLevel:
+			if int(cmd.Arg) >= len(s.Levels) {
+				diags = append(diags, diag(level, j, "level-arg-out-of-range", fmt.Sprintf("OpLevel arg %d indexes outside Levels (len %d)", cmd.Arg, len(s.Levels))))
+			}
+		case OpCine:
+			if int(cmd.Arg) >= len(s.Cutscenes) {
+				diags = append(diags, diag(level, j, "cutscene-arg-out-of-range", fmt.Sprintf("OpCine arg %d indexes outside Cutscenes (len %d)", cmd.Arg, len(s.Cutscenes))))
+			}
+		case OpFmv:
+			if int(cmd.Arg) >= len(s.Fmvs) {
+				diags = append(diags, diag(level, j, "fmv-arg-out-of-range", fmt.Sprintf("OpFmv arg %d indexes outside Fmvs (len %d)", cmd.Arg, len(s.Fmvs))))
+			}
+		case OpLoadPic:
+			if int(cmd.Arg) >= len(s.Levels) {
+				diags = append(diags, diag(level, j, "loadpic-arg-out-of-range", fmt.Sprintf("OpLoadPic arg %d indexes outside Levels (len %d)", cmd.Arg, len(s.Levels))))
+			}
+		case OpJumpToSequence:
+			// The gameflow's sequence array holds one entry per level plus
+			// the title flow at index 0, so a valid target is 0..len(Levels).
+			if int(cmd.Arg) > len(s.Levels) {
+				diags = append(diags, diag(level, j, "jump-target-invalid", fmt.Sprintf("OpJumpToSequence targets sequence %d, which doesn't exist", cmd.Arg)))
+			}
+		default:
+			if cmd.Op != OpDisable && (cmd.Op < 0 || cmd.Op > OpExitGame) {
+				diags = append(diags, diag(level, j, "unknown-opcode", fmt.Sprintf("opcode %d is outside the known range", int32(cmd.Op))))
+			}
+		}
+	}
+
+	if listDepth > 0 {
+		diags = append(diags, diag(level, len(flow)-1, "list-start-unmatched", "OpListStart has no matching OpListEnd"))
+	}
+
+	if len(flow) == 0 || flow[len(flow)-1].Op != OpEnd {
+		diags = append(diags, diag(level, len(flow)-1, "sequence-missing-end", "sequence does not end with OpEnd"))
+	}
+
+	return diags
+}
+
+func diag(level, command int, code, message string) Diagnostic {
+	return Diagnostic{
+		Level:    level,
+		Command:  command,
+		Severity: SeverityError,
+		Code:     code,
+		Message:  message,
+	}
+}
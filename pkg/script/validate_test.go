@@ -0,0 +1,106 @@
+package script
+
+import "testing"
+
+func hasCode(diags []Diagnostic, code string) bool {
+	for _, d := range diags {
+		if d.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name string
+		s    *Script
+		want string
+	}{
+		{
+			name: "clean sequence",
+			s: &Script{
+				Levels: []Level{{Flow: Sequence{{Op: OpEnd}}}},
+			},
+			want: "",
+		},
+		{
+			name: "missing end",
+			s: &Script{
+				Levels: []Level{{Flow: Sequence{{Op: OpTrack, Arg: 0}}}},
+			},
+			want: "sequence-missing-end",
+		},
+		{
+			name: "unmatched list start",
+			s: &Script{
+				Levels: []Level{{Flow: Sequence{{Op: OpListStart}, {Op: OpEnd}}}},
+			},
+			want: "list-start-unmatched",
+		},
+		{
+			name: "unmatched list end",
+			s: &Script{
+				Levels: []Level{{Flow: Sequence{{Op: OpListEnd}, {Op: OpEnd}}}},
+			},
+			want: "list-end-unmatched",
+		},
+		{
+			name: "nested lists too deep",
+			s: &Script{
+				Levels: []Level{{Flow: Sequence{{Op: OpListStart}, {Op: OpListStart}, {Op: OpListEnd}, {Op: OpListEnd}, {Op: OpEnd}}}},
+			},
+			want: "list-nesting-too-deep",
+		},
+		{
+			name: "level arg out of range",
+			s: &Script{
+				Levels: []Level{{Flow: Sequence{{Op: OpLevel, Arg: 5}, {Op: OpEnd}}}},
+			},
+			want: "level-arg-out-of-range",
+		},
+		{
+			name: "jump target invalid",
+			s: &Script{
+				Levels: []Level{{Flow: Sequence{{Op: OpJumpToSequence, Arg: 9}, {Op: OpEnd}}}},
+			},
+			want: "jump-target-invalid",
+		},
+		{
+			name: "unknown opcode",
+			s: &Script{
+				Levels: []Level{{Flow: Sequence{{Op: Opcode(999)}, {Op: OpEnd}}}},
+			},
+			want: "unknown-opcode",
+		},
+		{
+			name: "saved game, exit to title and exit game are not unknown",
+			s: &Script{
+				Levels: []Level{{Flow: Sequence{{Op: OpSavedGame}, {Op: OpExitToTitle}, {Op: OpExitGame}, {Op: OpEnd}}}},
+			},
+			want: "",
+		},
+		{
+			name: "demo level missing flow",
+			s: &Script{
+				Levels: []Level{{IsDemo: true}},
+			},
+			want: "demo-level-missing-flow",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			diags := Validate(c.s)
+			if c.want == "" {
+				if len(diags) != 0 {
+					t.Errorf("expected no diagnostics, got %v", diags)
+				}
+				return
+			}
+			if !hasCode(diags, c.want) {
+				t.Errorf("expected diagnostic %q, got %v", c.want, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package script
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/jw-dev/tombflow/pkg/script/internal/stream"
+)
+
+// Write encodes s back into the binary .dat/.tr* gameflow format read by
+// Read. Header counts (NumLevels, NumFmvs, NumCutscenes, NumDemoLevels,
+// NumChapterScreens, NumTitles) are re-derived from the slices on s rather
+// than trusted from any prior read, so a hand-edited Script round-trips
+// correctly even if levels were added or removed. ExtraStrings is likewise
+// padded or truncated to numExtraStrings, the fixed count Read always
+// expects for that section.
+func Write(w io.Writer, s *Script) error {
+	head := newHeaderFor(s)
+	if err := binary.Write(w, binary.LittleEndian, head); err != nil {
+		return err
+	}
+
+	levelNames := make([]string, len(s.Levels))
+	chapterPaths := make([]string, len(s.Levels))
+	levelPaths := make([]string, len(s.Levels))
+	for i, level := range s.Levels {
+		levelNames[i] = level.Name
+		chapterPaths[i] = level.Chapter
+		levelPaths[i] = level.Path
+	}
+
+	writers := []func() error{
+		func() error { return writeStringArray(w, levelNames, s.XorKey) },
+		func() error { return writeStringArray(w, chapterPaths, s.XorKey) },
+		func() error { return writeStringArray(w, s.Titles, s.XorKey) },
+		func() error { return writeStringArray(w, s.Fmvs, s.XorKey) },
+		func() error { return writeStringArray(w, levelPaths, s.XorKey) },
+		func() error { return writeStringArray(w, s.Cutscenes, s.XorKey) },
+		func() error { return writeSequenceArray(w, gameflowSequences(s)) },
+		func() error { return writeDemoLevels(w, demoLevelIndices(s)) },
+		func() error { return writeGameStrings(w, s.GameStrings, s.XorKey) },
+		func() error { return writeStringArray(w, paddedExtraStrings(s), s.XorKey) },
+	}
+	for _, f := range writers {
+		if err := f(); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < 4; i++ {
+		if err := writeStringArray(w, levelField(s, func(l Level) string { return l.Puzzles[i] }), s.XorKey); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if err := writeStringArray(w, levelField(s, func(l Level) string { return l.Pickups[i] }), s.XorKey); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < 4; i++ {
+		if err := writeStringArray(w, levelField(s, func(l Level) string { return l.Keys[i] }), s.XorKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// paddedExtraStrings returns s.ExtraStrings truncated or zero-padded to
+// exactly numExtraStrings entries, so a freshly-built or hand-edited Script
+// round-trips through Write/Read even if ExtraStrings wasn't pre-sized.
+func paddedExtraStrings(s *Script) []string {
+	out := make([]string, numExtraStrings)
+	copy(out, s.ExtraStrings)
+	return out
+}
+
+func levelField(s *Script, get func(Level) string) []string {
+	out := make([]string, len(s.Levels))
+	for i, level := range s.Levels {
+		out[i] = get(level)
+	}
+	return out
+}
+
+func gameflowSequences(s *Script) []Sequence {
+	seqs := make([]Sequence, len(s.Levels)+1)
+	seqs[0] = s.TitleFlow
+	for i, level := range s.Levels {
+		seqs[i+1] = level.Flow
+	}
+	return seqs
+}
+
+func demoLevelIndices(s *Script) []uint16 {
+	indices := []uint16{}
+	for i, level := range s.Levels {
+		if level.IsDemo {
+			indices = append(indices, uint16(i))
+		}
+	}
+	return indices
+}
+
+func newHeaderFor(s *Script) *header {
+	h := header{
+		Version:           s.Version,
+		GameflowSize:      s.GameflowSize,
+		FirstOption:       s.FirstOption,
+		TitleReplace:      s.TitleReplace,
+		OnDeathDemoMode:   s.OnDeathDemoMode,
+		OnDeathInGame:     s.OnDeathInGame,
+		DemoTime:          s.DemoTime,
+		OnDemoInterrupt:   s.OnDemoInterrupt,
+		OnDemoEnd:         s.OnDemoEnd,
+		NumLevels:         uint16(len(s.Levels)),
+		NumChapterScreens: uint16(len(s.Levels)),
+		NumTitles:         uint16(len(s.Titles)),
+		NumFmvs:           uint16(len(s.Fmvs)),
+		NumCutscenes:      uint16(len(s.Cutscenes)),
+		NumDemoLevels:     uint16(len(demoLevelIndices(s))),
+		TitleSoundId:      s.TitleSoundId,
+		SingleLevel:       s.SingleLevel,
+		Flags:             s.Flags,
+		XorKey:            s.XorKey,
+		LanguageId:        byte(s.Lang),
+		SecretSoundId:     s.SecretSoundId,
+	}
+	copy(h.Description[:], s.Description)
+	return &h
+}
+
+func newMultiByteArrayFromStrings(strs []string, xor byte) *multiByteArray {
+	offsets := make([]uint16, len(strs))
+	data := []uint8{}
+
+	for i, str := range strs {
+		offsets[i] = uint16(len(data))
+		bytes := []byte(str)
+		if xor > 0 {
+			for j := range bytes {
+				bytes[j] ^= xor
+			}
+		}
+		data = append(data, bytes...)
+	}
+
+	return newMultiByteArray(offsets, data)
+}
+
+// newMultiByteArrayFromSequences encodes each sequence through the bit
+// writer, mirroring decodeSequence's use of the bit reader: the opcode is
+// always a 16-bit word, and its argument (if any) is packed at the width
+// opcodeArgWidth gives it. For the current TR3/TR4 layout every width is
+// 16, so this produces the same word-aligned bytes as before. The opcode
+// width is fixed at 16 bits, so layouts that pack the argument into the
+// opcode word are not encoded.
+func newMultiByteArrayFromSequences(seqs []Sequence) (*multiByteArray, error) {
+	offsets := make([]uint16, len(seqs))
+	data := []uint8{}
+
+	for i, seq := range seqs {
+		offsets[i] = uint16(len(data))
+
+		var buf bytes.Buffer
+		bw := stream.NewWriter(&buf)
+		for _, cmd := range seq {
+			if err := bw.PushBits(uint32(uint16(cmd.Op)), 16); err != nil {
+				return nil, err
+			}
+			if width, ok := opcodeArgWidth[cmd.Op]; ok {
+				if err := bw.PushBits(uint32(cmd.Arg), width); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if err := bw.Flush(); err != nil {
+			return nil, err
+		}
+
+		data = append(data, buf.Bytes()...)
+	}
+
+	return newMultiByteArray(offsets, data), nil
+}
+
+func (m multiByteArray) write(w io.Writer) error {
+	if err := binary.Write(w, binary.LittleEndian, m.offsets); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, uint16(len(m.data))); err != nil {
+		return err
+	}
+	return binary.Write(w, binary.LittleEndian, m.data)
+}
+
+func writeStringArray(w io.Writer, strs []string, xor byte) error {
+	return newMultiByteArrayFromStrings(strs, xor).write(w)
+}
+
+func writeSequenceArray(w io.Writer, seqs []Sequence) error {
+	m, err := newMultiByteArrayFromSequences(seqs)
+	if err != nil {
+		return err
+	}
+	return m.write(w)
+}
+
+func writeDemoLevels(w io.Writer, levels []uint16) error {
+	return binary.Write(w, binary.LittleEndian, levels)
+}
+
+func writeGameStrings(w io.Writer, strs []string, xor byte) error {
+	if err := binary.Write(w, binary.LittleEndian, uint16(len(strs))); err != nil {
+		return err
+	}
+	return writeStringArray(w, strs, xor)
+}
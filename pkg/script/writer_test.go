@@ -0,0 +1,75 @@
+package script
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestWriteReadSequenceRoundTrip(t *testing.T) {
+	s := &Script{
+		Version: 1,
+		Levels: []Level{
+			{
+				Name: "Jungle",
+				Path: "JUNGLE.PSX",
+				Flow: Sequence{
+					{Op: OpPicture, Arg: 1},
+					{Op: OpListStart},
+					{Op: OpLevel, Arg: 0},
+					{Op: OpListEnd},
+					{Op: OpEnd},
+				},
+			},
+		},
+		TitleFlow: Sequence{{Op: OpFmv, Arg: 0}, {Op: OpEnd}},
+		Fmvs:      []string{"LOGO.RPL"},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, s); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	got, err := Read(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+
+	if !reflect.DeepEqual(got.TitleFlow, s.TitleFlow) {
+		t.Errorf("TitleFlow = %v, want %v", got.TitleFlow, s.TitleFlow)
+	}
+	if !reflect.DeepEqual(got.Levels[0].Flow, s.Levels[0].Flow) {
+		t.Errorf("Levels[0].Flow = %v, want %v", got.Levels[0].Flow, s.Levels[0].Flow)
+	}
+}
+
+func TestWriteExtraStringsIsPadded(t *testing.T) {
+	cases := []struct {
+		name         string
+		extraStrings []string
+	}{
+		{name: "nil", extraStrings: nil},
+		{name: "short", extraStrings: []string{"A", "B"}},
+		{name: "too long", extraStrings: make([]string, numExtraStrings+5)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s := &Script{ExtraStrings: c.extraStrings}
+
+			var buf bytes.Buffer
+			if err := Write(&buf, s); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+
+			got, err := Read(bytes.NewReader(buf.Bytes()))
+			if err != nil {
+				t.Fatalf("Read: %v", err)
+			}
+			if len(got.ExtraStrings) != numExtraStrings {
+				t.Errorf("len(ExtraStrings) = %d, want %d", len(got.ExtraStrings), numExtraStrings)
+			}
+		})
+	}
+}
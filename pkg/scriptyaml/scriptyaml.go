@@ -0,0 +1,30 @@
+// Package scriptyaml marshals and unmarshals script.Script (and its nested
+// Level and Command values) to a human-editable document, so a binary
+// gameflow can be extracted, hand-edited, and rebuilt with script.Write.
+//
+// The document is JSON rather than YAML: every field on script.Script is
+// already exported and JSON-friendly, and keeping to the standard library
+// avoids pulling in a YAML dependency for what is, structurally, the same
+// tree of maps/slices/scalars either format would produce.
+package scriptyaml
+
+import (
+	"encoding/json"
+
+	"github.com/jw-dev/tombflow/pkg/script"
+)
+
+// Marshal renders s as an indented JSON document suitable for hand-editing.
+func Marshal(s *script.Script) ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// Unmarshal parses a document produced by Marshal (or hand-authored in the
+// same shape) back into a Script ready for script.Write.
+func Unmarshal(data []byte) (*script.Script, error) {
+	s := &script.Script{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}